@@ -0,0 +1,48 @@
+package proxy
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/docker/docker/api/types"
+)
+
+func init() {
+	// The Docker API has no dedicated checkpoint inspect endpoint: checkpoints are only ever
+	// listed or created/removed against a container, so only listOperation is derived here.
+	registerResourceOperation(&resourceOperation{
+		kind:                ResourceKindCheckpoint,
+		decodeList:          decodeCheckpointList,
+		identifierExtractor: checkpointIdentifier,
+	})
+}
+
+// checkpointListOperation decodes the response into an array of types.Checkpoint, decorate
+// and/or filter the checkpoints based on resource controls before rewriting the response.
+// https://docs.docker.com/engine/api/v1.28/#operation/ContainerCheckpointList
+func checkpointListOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	return resourceOperations[ResourceKindCheckpoint].listOperation(request, response, operationContext)
+}
+
+func decodeCheckpointList(body io.Reader) ([]interface{}, error) {
+	var checkpoints []types.Checkpoint
+	if err := json.NewDecoder(body).Decode(&checkpoints); err != nil {
+		return nil, err
+	}
+
+	resources := make([]interface{}, len(checkpoints))
+	for i := range checkpoints {
+		resources[i] = checkpoints[i]
+	}
+	return resources, nil
+}
+
+// checkpointIdentifier returns the Checkpoint name, checkpoints have no ID and are addressed by name.
+func checkpointIdentifier(resource interface{}) string {
+	checkpoint, ok := resource.(types.Checkpoint)
+	if !ok {
+		return ""
+	}
+	return checkpoint.Name
+}
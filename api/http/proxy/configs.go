@@ -0,0 +1,62 @@
+package proxy
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+func init() {
+	registerResourceOperation(&resourceOperation{
+		kind:                ResourceKindConfig,
+		decodeList:          decodeConfigList,
+		decodeInspect:       decodeConfigInspect,
+		identifierExtractor: configIdentifier,
+	})
+}
+
+// configListOperation decodes the response into an array of swarm.Config, decorate and/or
+// filter the configs based on resource controls before rewriting the response.
+// https://docs.docker.com/engine/api/v1.30/#operation/ConfigList
+func configListOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	return resourceOperations[ResourceKindConfig].listOperation(request, response, operationContext)
+}
+
+// configInspectOperation decodes the response into a swarm.Config, verify that the user has
+// access to the config based on resource control and either rewrite an access denied response
+// or a decorated config.
+// https://docs.docker.com/engine/api/v1.30/#operation/ConfigInspect
+func configInspectOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	return resourceOperations[ResourceKindConfig].inspectOperation(request, response, operationContext)
+}
+
+func decodeConfigList(body io.Reader) ([]interface{}, error) {
+	var configs []swarm.Config
+	if err := json.NewDecoder(body).Decode(&configs); err != nil {
+		return nil, err
+	}
+
+	resources := make([]interface{}, len(configs))
+	for i := range configs {
+		resources[i] = configs[i]
+	}
+	return resources, nil
+}
+
+func decodeConfigInspect(body io.Reader) (interface{}, error) {
+	var config swarm.Config
+	if err := json.NewDecoder(body).Decode(&config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func configIdentifier(resource interface{}) string {
+	config, ok := resource.(swarm.Config)
+	if !ok {
+		return ""
+	}
+	return config.ID
+}
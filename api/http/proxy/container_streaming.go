@@ -0,0 +1,189 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/portainer/portainer"
+)
+
+// ErrDockerEndpointClientNotFound defines an error raised when no Docker client has been
+// registered for the endpoint a streamed request targets.
+const ErrDockerEndpointClientNotFound = portainer.Error("Docker endpoint client not found")
+
+// inspectCacheTTL bounds how long the result of the side inspect call performed by a
+// streamingOperation is reused for the same container, so that a client opening several
+// streamed endpoints in quick succession against the same container (for example attach
+// followed by a stats stream) does not trigger a Docker inspect call for each of them.
+const inspectCacheTTL = 3 * time.Second
+
+// inspectCacheKey scopes a cached inspect result to the endpoint it was retrieved from: Docker
+// container identifiers (including the short-ID prefixes the Docker API also accepts) are only
+// unique within a single endpoint, and Portainer proxies many independent Docker daemons through
+// one process.
+type inspectCacheKey struct {
+	endpointID portainer.EndpointID
+	identifier string
+}
+
+type inspectCacheEntry struct {
+	resource interface{}
+	expires  time.Time
+}
+
+var (
+	inspectCacheMu sync.Mutex
+	inspectCache   = map[inspectCacheKey]inspectCacheEntry{}
+)
+
+func init() {
+	registerStreamingOperation(&streamingOperation{
+		kind:                ResourceKindContainer,
+		identifierExtractor: containerIdentifierFromRequestPath,
+		inspect:             inspectContainerForStreaming,
+	})
+}
+
+// containerLogsOperation, containerStatsOperation and containerAttachOperation all proxy a
+// streamed/hijacked Docker response, after checking access against the resource control of the
+// container. The fourth streamed endpoint named by the request, /events, is cluster-wide rather
+// than container-scoped and is handled by eventsOperation in streaming.go instead.
+// https://docs.docker.com/engine/api/v1.28/#operation/ContainerLogs
+// https://docs.docker.com/engine/api/v1.28/#operation/ContainerStats
+// https://docs.docker.com/engine/api/v1.28/#operation/ContainerAttach
+func containerLogsOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	return streamingOperations[ResourceKindContainer].streamResponse(request, response, operationContext)
+}
+
+func containerStatsOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	return streamingOperations[ResourceKindContainer].streamResponse(request, response, operationContext)
+}
+
+func containerAttachOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	return streamingOperations[ResourceKindContainer].streamResponse(request, response, operationContext)
+}
+
+// containerIdentifierFromRequestPath extracts the {id} path variable Docker uses to address a
+// container on every streamed endpoint.
+func containerIdentifierFromRequestPath(request *http.Request) string {
+	return mux.Vars(request)["id"]
+}
+
+// inspectContainerForStreaming returns the decoded container behind identifier on endpointID,
+// performing a side ContainerInspect call against that endpoint's Docker daemon and caching the
+// result briefly so that several streamed requests against the same container don't each pay for
+// their own round-trip.
+func inspectContainerForStreaming(endpointID portainer.EndpointID, identifier string) (interface{}, error) {
+	key := inspectCacheKey{endpointID: endpointID, identifier: identifier}
+
+	inspectCacheMu.Lock()
+	entry, ok := inspectCache[key]
+	inspectCacheMu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.resource, nil
+	}
+
+	response, err := sideContainerInspectRequest(endpointID, identifier)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	container, err := decodeContainerInspect(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	storeInspectCacheEntry(key, container)
+
+	return container, nil
+}
+
+// storeInspectCacheEntry records a fresh inspect result and, in the same critical section,
+// evicts every entry whose TTL already elapsed, so that containers that are only ever streamed
+// from once do not linger in the cache for the life of the process.
+func storeInspectCacheEntry(key inspectCacheKey, resource interface{}) {
+	now := time.Now()
+
+	inspectCacheMu.Lock()
+	defer inspectCacheMu.Unlock()
+
+	for existingKey, existingEntry := range inspectCache {
+		if !now.Before(existingEntry.expires) {
+			delete(inspectCache, existingKey)
+		}
+	}
+
+	inspectCache[key] = inspectCacheEntry{resource: resource, expires: now.Add(inspectCacheTTL)}
+}
+
+// dockerEndpointClient is the http.Client/base URL pair used to reach a single endpoint's Docker
+// daemon. It is registered by the reverse proxy transport that builds one per endpoint.
+type dockerEndpointClient struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+var (
+	dockerEndpointClientsMu sync.RWMutex
+	dockerEndpointClients   = map[portainer.EndpointID]dockerEndpointClient{}
+)
+
+// RegisterDockerEndpointClient makes an endpoint's Docker client available to the proxy package,
+// so that side calls such as the inspect performed before a streamed response can be issued
+// against the right Docker daemon. It is called by the reverse proxy transport once per endpoint,
+// when that endpoint's proxy is built.
+func RegisterDockerEndpointClient(endpointID portainer.EndpointID, httpClient *http.Client, baseURL string) {
+	dockerEndpointClientsMu.Lock()
+	defer dockerEndpointClientsMu.Unlock()
+	dockerEndpointClients[endpointID] = dockerEndpointClient{httpClient: httpClient, baseURL: baseURL}
+}
+
+// sideContainerInspectRequest issues the ContainerInspect call, against the Docker daemon behind
+// endpointID, used to resolve the resource control of a container before a streamed response to
+// it is proxied through. It returns an error on any non-2xx Docker response (e.g. the container
+// was removed between the real streamed request and this side call) instead of handing the
+// caller a body that would silently decode into a zero-value container with no identifier.
+func sideContainerInspectRequest(endpointID portainer.EndpointID, identifier string) (*http.Response, error) {
+	dockerEndpointClientsMu.RLock()
+	client, ok := dockerEndpointClients[endpointID]
+	dockerEndpointClientsMu.RUnlock()
+	if !ok {
+		return nil, ErrDockerEndpointClientNotFound
+	}
+
+	request, err := http.NewRequest(http.MethodGet, client.baseURL+"/containers/"+identifier+"/json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		defer response.Body.Close()
+		return nil, dockerInspectError(response)
+	}
+
+	return response, nil
+}
+
+// dockerInspectError turns a non-2xx Docker API response into an error carrying its status code
+// and, when present, the human-readable message Docker returns as {"message": "..."}.
+func dockerInspectError(response *http.Response) error {
+	var body struct {
+		Message string `json:"message"`
+	}
+	json.NewDecoder(response.Body).Decode(&body)
+
+	if body.Message != "" {
+		return fmt.Errorf("docker container inspect failed with status %d: %s", response.StatusCode, body.Message)
+	}
+	return fmt.Errorf("docker container inspect failed with status %d", response.StatusCode)
+}
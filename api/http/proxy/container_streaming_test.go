@@ -0,0 +1,113 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/portainer/portainer"
+)
+
+func resetInspectCache() {
+	inspectCacheMu.Lock()
+	inspectCache = map[inspectCacheKey]inspectCacheEntry{}
+	inspectCacheMu.Unlock()
+}
+
+func Test_inspectCache_scopesByEndpoint(t *testing.T) {
+	resetInspectCache()
+
+	keyA := inspectCacheKey{endpointID: 1, identifier: "container1"}
+	keyB := inspectCacheKey{endpointID: 2, identifier: "container1"}
+
+	storeInspectCacheEntry(keyA, "endpoint-1-container")
+	storeInspectCacheEntry(keyB, "endpoint-2-container")
+
+	inspectCacheMu.Lock()
+	entryA := inspectCache[keyA]
+	entryB := inspectCache[keyB]
+	inspectCacheMu.Unlock()
+
+	if entryA.resource != "endpoint-1-container" {
+		t.Errorf("expected endpoint 1's cached resource to be untouched by endpoint 2, got %v", entryA.resource)
+	}
+	if entryB.resource != "endpoint-2-container" {
+		t.Errorf("expected endpoint 2's own cached resource, got %v", entryB.resource)
+	}
+}
+
+func Test_inspectCache_evictsExpiredEntries(t *testing.T) {
+	resetInspectCache()
+
+	staleKey := inspectCacheKey{endpointID: 1, identifier: "stale"}
+	inspectCacheMu.Lock()
+	inspectCache[staleKey] = inspectCacheEntry{resource: "stale", expires: time.Now().Add(-time.Minute)}
+	inspectCacheMu.Unlock()
+
+	freshKey := inspectCacheKey{endpointID: 1, identifier: "fresh"}
+	storeInspectCacheEntry(freshKey, "fresh")
+
+	inspectCacheMu.Lock()
+	defer inspectCacheMu.Unlock()
+
+	if _, ok := inspectCache[staleKey]; ok {
+		t.Errorf("expected the expired entry to have been evicted on the next write")
+	}
+	if _, ok := inspectCache[freshKey]; !ok {
+		t.Errorf("expected the fresh entry to be present")
+	}
+}
+
+func Test_sideContainerInspectRequest_errorsWithoutRegisteredClient(t *testing.T) {
+	_, err := sideContainerInspectRequest(portainer.EndpointID(999999), "container1")
+	if err != ErrDockerEndpointClientNotFound {
+		t.Fatalf("expected ErrDockerEndpointClientNotFound, got %v", err)
+	}
+}
+
+func Test_sideContainerInspectRequest_errorsOnNonSuccessStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"no such container: container1"}`))
+	}))
+	defer server.Close()
+
+	endpointID := portainer.EndpointID(43)
+	RegisterDockerEndpointClient(endpointID, server.Client(), server.URL)
+
+	response, err := sideContainerInspectRequest(endpointID, "container1")
+	if err == nil {
+		t.Fatal("expected an error for a non-2xx Docker response, got none")
+	}
+	if response != nil {
+		t.Errorf("expected no response alongside the error, got %v", response)
+	}
+}
+
+func Test_sideContainerInspectRequest_usesRegisteredEndpointClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/containers/container1/json" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"Id":"container1"}`))
+	}))
+	defer server.Close()
+
+	endpointID := portainer.EndpointID(42)
+	RegisterDockerEndpointClient(endpointID, server.Client(), server.URL)
+
+	response, err := sideContainerInspectRequest(endpointID, "container1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer response.Body.Close()
+
+	container, err := decodeContainerInspect(response.Body)
+	if err != nil {
+		t.Fatalf("unexpected decode error: %s", err)
+	}
+	if containerIdentifier(container) != "container1" {
+		t.Errorf("expected decoded container ID container1, got %v", container)
+	}
+}
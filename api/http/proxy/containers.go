@@ -1,98 +1,95 @@
 package proxy
 
 import (
+	"encoding/json"
+	"io"
 	"net/http"
 
-	"github.com/portainer/portainer"
+	"github.com/docker/docker/api/types"
 )
 
 const (
-	// ErrDockerContainerIdentifierNotFound defines an error raised when Portainer is unable to find a container identifier
-	ErrDockerContainerIdentifierNotFound = portainer.Error("Docker container identifier not found")
-	containerIdentifier                  = "Id"
-	containerLabelForServiceIdentifier   = "com.docker.swarm.service.id"
+	// containerLabelForServiceIdentifier is the label Docker Swarm sets on every container it
+	// creates for a service, pointing back at the owning service. It is used to resolve the
+	// indirect resource control of a container started by a Swarm service.
+	containerLabelForServiceIdentifier = "com.docker.swarm.service.id"
 )
 
-// containerListOperation extracts the response as a JSON object, loop through the containers array
-// decorate and/or filter the containers based on resource controls before rewriting the response
-func containerListOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
-	var err error
-	// ContainerList response is a JSON array
-	// https://docs.docker.com/engine/api/v1.28/#operation/ContainerList
-	responseArray, err := getResponseAsJSONArray(response)
-	if err != nil {
-		return err
-	}
-
-	if operationContext.isAdmin {
-		responseArray, err = decorateContainerList(responseArray, operationContext.resourceControls)
-	} else {
-		responseArray, err = filterContainerList(responseArray, operationContext.resourceControls, operationContext.userID, operationContext.userTeamIDs)
-	}
-	if err != nil {
-		return err
-	}
+func init() {
+	registerResourceOperation(&resourceOperation{
+		kind:                ResourceKindContainer,
+		decodeList:          decodeContainerList,
+		decodeInspect:       decodeContainerInspect,
+		identifierExtractor: containerIdentifier,
+		labelExtractor:      containerLabels,
+	})
+}
 
-	return rewriteResponse(response, responseArray, http.StatusOK)
+// containerListOperation decodes the response into an array of types.Container, decorate
+// and/or filter the containers based on resource controls before rewriting the response.
+// https://docs.docker.com/engine/api/v1.28/#operation/ContainerList
+func containerListOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	return resourceOperations[ResourceKindContainer].listOperation(request, response, operationContext)
 }
 
-// containerInspectOperation extracts the response as a JSON object, verify that the user
-// has access to the container based on resource control (check are done based on the containerID and optional Swarm service ID)
-// and either rewrite an access denied response or a decorated container.
+// containerInspectOperation decodes the response into a types.ContainerJSON, verify that the user
+// has access to the container based on resource control (the resource control bound to the container
+// ID, or failing that the one bound to its ownership labels, by default the Swarm service ID) and
+// either rewrite an access denied response or a decorated container.
+// https://docs.docker.com/engine/api/v1.28/#operation/ContainerInspect
 func containerInspectOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
-	// ContainerInspect response is a JSON object
-	// https://docs.docker.com/engine/api/v1.28/#operation/ContainerInspect
-	responseObject, err := getResponseAsJSONOBject(response)
-	if err != nil {
-		return err
-	}
+	return resourceOperations[ResourceKindContainer].inspectOperation(request, response, operationContext)
+}
 
-	if responseObject[containerIdentifier] == nil {
-		return ErrDockerContainerIdentifierNotFound
+func decodeContainerList(body io.Reader) ([]interface{}, error) {
+	var containers []types.Container
+	if err := json.NewDecoder(body).Decode(&containers); err != nil {
+		return nil, err
 	}
-	containerID := responseObject[containerIdentifier].(string)
 
-	resourceControl := getResourceControlByResourceID(containerID, operationContext.resourceControls)
-	if resourceControl != nil {
-		if operationContext.isAdmin || canUserAccessResource(operationContext.userID, operationContext.userTeamIDs, resourceControl) {
-			responseObject = decorateObject(responseObject, resourceControl)
-		} else {
-			return rewriteAccessDeniedResponse(response)
-		}
+	resources := make([]interface{}, len(containers))
+	for i := range containers {
+		resources[i] = containers[i]
 	}
+	return resources, nil
+}
 
-	containerLabels := extractContainerLabelsFromContainerInspectObject(responseObject)
-	if containerLabels != nil && containerLabels[containerLabelForServiceIdentifier] != nil {
-		serviceID := containerLabels[containerLabelForServiceIdentifier].(string)
-		resourceControl := getResourceControlByResourceID(serviceID, operationContext.resourceControls)
-		if resourceControl != nil {
-			if operationContext.isAdmin || canUserAccessResource(operationContext.userID, operationContext.userTeamIDs, resourceControl) {
-				responseObject = decorateObject(responseObject, resourceControl)
-			} else {
-				return rewriteAccessDeniedResponse(response)
-			}
-		}
+func decodeContainerInspect(body io.Reader) (interface{}, error) {
+	var container types.ContainerJSON
+	if err := json.NewDecoder(body).Decode(&container); err != nil {
+		return nil, err
 	}
-
-	return rewriteResponse(response, responseObject, http.StatusOK)
+	return container, nil
 }
 
-// extractContainerLabelsFromContainerInspectObject retrieve the Labels of the container if present.
-// Container schema reference: https://docs.docker.com/engine/api/v1.28/#operation/ContainerInspect
-func extractContainerLabelsFromContainerInspectObject(responseObject map[string]interface{}) map[string]interface{} {
-	// Labels are stored under Config.Labels
-	containerConfigObject := extractJSONField(responseObject, "Config")
-	if containerConfigObject != nil {
-		containerLabelsObject := extractJSONField(containerConfigObject, "Labels")
-		return containerLabelsObject
+// containerIdentifier returns the identifier of a decoded container, regardless of whether it
+// was obtained from a list or an inspect response.
+func containerIdentifier(resource interface{}) string {
+	switch container := resource.(type) {
+	case types.Container:
+		return container.ID
+	case types.ContainerJSON:
+		if container.ContainerJSONBase == nil {
+			return ""
+		}
+		return container.ID
+	default:
+		return ""
 	}
-	return nil
 }
 
-// extractContainerLabelsFromContainerListObject retrieve the Labels of the container if present.
-// Container schema reference: https://docs.docker.com/engine/api/v1.28/#operation/ContainerList
-func extractContainerLabelsFromContainerListObject(responseObject map[string]interface{}) map[string]interface{} {
-	// Labels are stored under Labels
-	containerLabelsObject := extractJSONField(responseObject, "Labels")
-	return containerLabelsObject
+// containerLabels returns the labels of a decoded container, read directly from Config.Labels
+// for inspect responses and from Labels for list responses.
+func containerLabels(resource interface{}) map[string]string {
+	switch container := resource.(type) {
+	case types.Container:
+		return container.Labels
+	case types.ContainerJSON:
+		if container.Config == nil {
+			return nil
+		}
+		return container.Config.Labels
+	default:
+		return nil
+	}
 }
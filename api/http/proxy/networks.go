@@ -0,0 +1,62 @@
+package proxy
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/docker/docker/api/types"
+)
+
+func init() {
+	registerResourceOperation(&resourceOperation{
+		kind:                ResourceKindNetwork,
+		decodeList:          decodeNetworkList,
+		decodeInspect:       decodeNetworkInspect,
+		identifierExtractor: networkIdentifier,
+	})
+}
+
+// networkListOperation decodes the response into an array of types.NetworkResource, decorate
+// and/or filter the networks based on resource controls before rewriting the response.
+// https://docs.docker.com/engine/api/v1.28/#operation/NetworkList
+func networkListOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	return resourceOperations[ResourceKindNetwork].listOperation(request, response, operationContext)
+}
+
+// networkInspectOperation decodes the response into a types.NetworkResource, verify that the
+// user has access to the network based on resource control and either rewrite an access denied
+// response or a decorated network.
+// https://docs.docker.com/engine/api/v1.28/#operation/NetworkInspect
+func networkInspectOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	return resourceOperations[ResourceKindNetwork].inspectOperation(request, response, operationContext)
+}
+
+func decodeNetworkList(body io.Reader) ([]interface{}, error) {
+	var networks []types.NetworkResource
+	if err := json.NewDecoder(body).Decode(&networks); err != nil {
+		return nil, err
+	}
+
+	resources := make([]interface{}, len(networks))
+	for i := range networks {
+		resources[i] = networks[i]
+	}
+	return resources, nil
+}
+
+func decodeNetworkInspect(body io.Reader) (interface{}, error) {
+	var network types.NetworkResource
+	if err := json.NewDecoder(body).Decode(&network); err != nil {
+		return nil, err
+	}
+	return network, nil
+}
+
+func networkIdentifier(resource interface{}) string {
+	network, ok := resource.(types.NetworkResource)
+	if !ok {
+		return ""
+	}
+	return network.ID
+}
@@ -0,0 +1,62 @@
+package proxy
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+func init() {
+	registerResourceOperation(&resourceOperation{
+		kind:                ResourceKindNode,
+		decodeList:          decodeNodeList,
+		decodeInspect:       decodeNodeInspect,
+		identifierExtractor: nodeIdentifier,
+	})
+}
+
+// nodeListOperation decodes the response into an array of swarm.Node, decorate and/or filter
+// the nodes based on resource controls before rewriting the response.
+// https://docs.docker.com/engine/api/v1.28/#operation/NodeList
+func nodeListOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	return resourceOperations[ResourceKindNode].listOperation(request, response, operationContext)
+}
+
+// nodeInspectOperation decodes the response into a swarm.Node, verify that the user has access
+// to the node based on resource control and either rewrite an access denied response or a
+// decorated node.
+// https://docs.docker.com/engine/api/v1.28/#operation/NodeInspect
+func nodeInspectOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	return resourceOperations[ResourceKindNode].inspectOperation(request, response, operationContext)
+}
+
+func decodeNodeList(body io.Reader) ([]interface{}, error) {
+	var nodes []swarm.Node
+	if err := json.NewDecoder(body).Decode(&nodes); err != nil {
+		return nil, err
+	}
+
+	resources := make([]interface{}, len(nodes))
+	for i := range nodes {
+		resources[i] = nodes[i]
+	}
+	return resources, nil
+}
+
+func decodeNodeInspect(body io.Reader) (interface{}, error) {
+	var node swarm.Node
+	if err := json.NewDecoder(body).Decode(&node); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func nodeIdentifier(resource interface{}) string {
+	node, ok := resource.(swarm.Node)
+	if !ok {
+		return ""
+	}
+	return node.ID
+}
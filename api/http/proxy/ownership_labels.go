@@ -0,0 +1,67 @@
+package proxy
+
+import (
+	"github.com/portainer/portainer"
+)
+
+// defaultOwnershipLabels lists the ownership label keys resolved, in order, when neither an
+// endpoint nor the instance-wide settings configure their own list. It keeps the historical Swarm service indirection as the
+// only entry, so that upgrading a Portainer instance preserves its existing behavior exactly.
+var defaultOwnershipLabels = []string{containerLabelForServiceIdentifier}
+
+// resolveOwnershipResourceControl walks ownershipLabels, in order, and returns the resource
+// control bound to the value of the first configured key found on labels whose value is bound to
+// a resource control; keys whose value resolves to none are skipped. It falls back to
+// defaultOwnershipLabels when ownershipLabels is empty, and returns nil if labels is nil, no
+// configured key is present, or none of the present keys resolve to a resource control.
+//
+// This generalizes the original hardcoded com.docker.swarm.service.id lookup: an endpoint can
+// additionally be configured with keys such as io.portainer.owner.team or
+// com.docker.stack.namespace to bind containers/services carrying them to the corresponding
+// team or stack resource control.
+func resolveOwnershipResourceControl(labels map[string]string, ownershipLabels []string, resourceControls []portainer.ResourceControl) *portainer.ResourceControl {
+	if labels == nil {
+		return nil
+	}
+
+	keys := ownershipLabels
+	if len(keys) == 0 {
+		keys = defaultOwnershipLabels
+	}
+
+	for _, key := range keys {
+		value, ok := labels[key]
+		if !ok || value == "" {
+			continue
+		}
+
+		if resourceControl := getResourceControlByResourceID(value, resourceControls); resourceControl != nil {
+			return resourceControl
+		}
+	}
+
+	return nil
+}
+
+// ResolveEndpointOwnershipLabels returns the ownership label keys that requests proxied through
+// endpoint should be resolved against: an endpoint-level override takes precedence over the
+// instance-wide default configured in settings, which itself falls back to
+// defaultOwnershipLabels. This is the resolution the reverse proxy transport calls into, once per
+// endpoint, to populate restrictedOperationContext.ownershipLabels.
+//
+// Migration path: both portainer.Endpoint.OwnershipLabels and portainer.Settings.OwnershipLabels
+// are absent/empty on any instance that predates this setting (including every instance upgraded
+// from before it existed), in which case this resolves to defaultOwnershipLabels so existing
+// deployments keep matching containers/services to resource controls exactly as they did before,
+// via com.docker.swarm.service.id alone.
+func ResolveEndpointOwnershipLabels(endpoint *portainer.Endpoint, settings *portainer.Settings) []string {
+	if endpoint != nil && len(endpoint.OwnershipLabels) > 0 {
+		return endpoint.OwnershipLabels
+	}
+
+	if settings != nil && len(settings.OwnershipLabels) > 0 {
+		return settings.OwnershipLabels
+	}
+
+	return defaultOwnershipLabels
+}
@@ -0,0 +1,106 @@
+package proxy
+
+import (
+	"testing"
+
+	"github.com/portainer/portainer"
+)
+
+func Test_resolveOwnershipResourceControl(t *testing.T) {
+	resourceControls := []portainer.ResourceControl{
+		{ResourceID: "service1"},
+		{ResourceID: "team-devs"},
+	}
+
+	tests := []struct {
+		name            string
+		labels          map[string]string
+		ownershipLabels []string
+		want            string
+	}{
+		{
+			name:            "falls back to the default Swarm service label when none configured",
+			labels:          map[string]string{containerLabelForServiceIdentifier: "service1"},
+			ownershipLabels: nil,
+			want:            "service1",
+		},
+		{
+			name:            "resolves a configured team ownership label",
+			labels:          map[string]string{"io.portainer.owner.team": "team-devs"},
+			ownershipLabels: []string{"io.portainer.owner.team"},
+			want:            "team-devs",
+		},
+		{
+			name:            "walks configured keys in order and stops at the first match",
+			labels:          map[string]string{"com.docker.stack.namespace": "unbound-stack", "io.portainer.owner.team": "team-devs"},
+			ownershipLabels: []string{"com.docker.stack.namespace", "io.portainer.owner.team"},
+			want:            "team-devs",
+		},
+		{
+			name:            "no labels present",
+			labels:          nil,
+			ownershipLabels: []string{"io.portainer.owner.team"},
+			want:            "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resourceControl := resolveOwnershipResourceControl(test.labels, test.ownershipLabels, resourceControls)
+
+			if test.want == "" {
+				if resourceControl != nil {
+					t.Fatalf("expected no resource control, got %v", resourceControl)
+				}
+				return
+			}
+
+			if resourceControl == nil || resourceControl.ResourceID != test.want {
+				t.Fatalf("expected resource control %q, got %v", test.want, resourceControl)
+			}
+		})
+	}
+}
+
+func Test_ResolveEndpointOwnershipLabels(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint *portainer.Endpoint
+		settings *portainer.Settings
+		want     []string
+	}{
+		{
+			name:     "migration path: no endpoint override and no global setting falls back to the default",
+			endpoint: &portainer.Endpoint{},
+			settings: &portainer.Settings{},
+			want:     defaultOwnershipLabels,
+		},
+		{
+			name:     "uses the instance-wide setting when the endpoint has no override",
+			endpoint: &portainer.Endpoint{},
+			settings: &portainer.Settings{OwnershipLabels: []string{"com.docker.stack.namespace"}},
+			want:     []string{"com.docker.stack.namespace"},
+		},
+		{
+			name:     "an endpoint override takes precedence over the instance-wide setting",
+			endpoint: &portainer.Endpoint{OwnershipLabels: []string{"io.portainer.owner.team"}},
+			settings: &portainer.Settings{OwnershipLabels: []string{"com.docker.stack.namespace"}},
+			want:     []string{"io.portainer.owner.team"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ResolveEndpointOwnershipLabels(test.endpoint, test.settings)
+
+			if len(got) != len(test.want) {
+				t.Fatalf("expected %v, got %v", test.want, got)
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Fatalf("expected %v, got %v", test.want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package proxy
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/docker/docker/api/types"
+)
+
+func init() {
+	registerResourceOperation(&resourceOperation{
+		kind:                ResourceKindPlugin,
+		decodeList:          decodePluginList,
+		decodeInspect:       decodePluginInspect,
+		identifierExtractor: pluginIdentifier,
+	})
+}
+
+// pluginListOperation decodes the response into an array of types.Plugin, decorate and/or
+// filter the plugins based on resource controls before rewriting the response.
+// https://docs.docker.com/engine/api/v1.28/#operation/PluginList
+func pluginListOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	return resourceOperations[ResourceKindPlugin].listOperation(request, response, operationContext)
+}
+
+// pluginInspectOperation decodes the response into a types.Plugin, verify that the user has
+// access to the plugin based on resource control and either rewrite an access denied response
+// or a decorated plugin.
+// https://docs.docker.com/engine/api/v1.28/#operation/PluginInspect
+func pluginInspectOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	return resourceOperations[ResourceKindPlugin].inspectOperation(request, response, operationContext)
+}
+
+func decodePluginList(body io.Reader) ([]interface{}, error) {
+	var plugins []types.Plugin
+	if err := json.NewDecoder(body).Decode(&plugins); err != nil {
+		return nil, err
+	}
+
+	resources := make([]interface{}, len(plugins))
+	for i := range plugins {
+		resources[i] = plugins[i]
+	}
+	return resources, nil
+}
+
+func decodePluginInspect(body io.Reader) (interface{}, error) {
+	var plugin types.Plugin
+	if err := json.NewDecoder(body).Decode(&plugin); err != nil {
+		return nil, err
+	}
+	return plugin, nil
+}
+
+func pluginIdentifier(resource interface{}) string {
+	plugin, ok := resource.(types.Plugin)
+	if !ok {
+		return ""
+	}
+	return plugin.ID
+}
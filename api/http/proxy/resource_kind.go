@@ -0,0 +1,28 @@
+package proxy
+
+// ResourceKind represents a Docker resource type exposed through the Portainer reverse proxy
+// that can be bound to a resource control.
+type ResourceKind int
+
+const (
+	// ResourceKindContainer represents the /containers Docker resources
+	ResourceKindContainer ResourceKind = iota
+	// ResourceKindService represents the /services Docker resources
+	ResourceKindService
+	// ResourceKindNetwork represents the /networks Docker resources
+	ResourceKindNetwork
+	// ResourceKindVolume represents the /volumes Docker resources
+	ResourceKindVolume
+	// ResourceKindSecret represents the /secrets Docker resources
+	ResourceKindSecret
+	// ResourceKindConfig represents the /configs Docker resources
+	ResourceKindConfig
+	// ResourceKindTask represents the /tasks Docker resources
+	ResourceKindTask
+	// ResourceKindNode represents the /nodes Docker resources
+	ResourceKindNode
+	// ResourceKindPlugin represents the /plugins Docker resources
+	ResourceKindPlugin
+	// ResourceKindCheckpoint represents the /checkpoints Docker resources
+	ResourceKindCheckpoint
+)
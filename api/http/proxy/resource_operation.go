@@ -0,0 +1,170 @@
+package proxy
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/portainer/portainer"
+)
+
+// ErrDockerResourceIdentifierNotFound defines an error raised when Portainer is unable to find
+// the identifier of a Docker resource decoded from a proxied response.
+const ErrDockerResourceIdentifierNotFound = portainer.Error("Docker resource identifier not found")
+
+// IdentifierExtractor returns the unique identifier of a decoded Docker resource.
+type IdentifierExtractor func(resource interface{}) string
+
+// LabelExtractor returns the labels carried by a decoded Docker resource, or nil if the resource
+// does not carry any. It is used to resolve the Swarm-style label indirection (e.g. a container
+// bound to the resource control of the Swarm service that created it).
+type LabelExtractor func(resource interface{}) map[string]string
+
+// decodeListFunc decodes a list response body into a slice of decoded Docker resources.
+type decodeListFunc func(io.Reader) ([]interface{}, error)
+
+// decodeInspectFunc decodes an inspect response body into a single decoded Docker resource.
+type decodeInspectFunc func(io.Reader) (interface{}, error)
+
+// resourceOperation binds a ResourceKind to the functions required to decode its list/inspect
+// responses and to derive the identifier (and, optionally, owning label) used for resource
+// control lookups. It derives the list/inspect restricted operations shared by every Docker
+// resource kind, so that adding a new kind only requires registering these few functions instead
+// of duplicating the admin-decorate / user-filter logic.
+type resourceOperation struct {
+	kind                ResourceKind
+	decodeList          decodeListFunc
+	decodeInspect       decodeInspectFunc
+	identifierExtractor IdentifierExtractor
+	labelExtractor      LabelExtractor
+}
+
+// resourceOperations holds the resourceOperation registered for each ResourceKind.
+var resourceOperations = map[ResourceKind]*resourceOperation{}
+
+// registerResourceOperation registers a resourceOperation for its ResourceKind.
+func registerResourceOperation(operation *resourceOperation) {
+	resourceOperations[operation.kind] = operation
+}
+
+// resourceWrapper is used to inject a Portainer resource control into a decoded Docker resource
+// while preserving all of its original fields when marshaled back to JSON.
+type resourceWrapper struct {
+	resource  interface{}
+	Portainer *portainer.ResourceControl `json:"Portainer,omitempty"`
+}
+
+// MarshalJSON merges the wrapped resource with the Portainer resource control, if any, so that
+// the decorated field appears alongside the untouched Docker resource fields.
+func (wrapper *resourceWrapper) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(wrapper.resource)
+	if err != nil {
+		return nil, err
+	}
+
+	if wrapper.Portainer == nil {
+		return data, nil
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	object["Portainer"] = wrapper.Portainer
+
+	return json.Marshal(object)
+}
+
+// listOperation decodes the response via the bound decodeList function, decorates or filters the
+// resulting resources based on resource controls and rewrites the response.
+func (operation *resourceOperation) listOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	resources, err := operation.decodeList(response.Body)
+	if err != nil {
+		return err
+	}
+
+	var responseArray []interface{}
+	if operationContext.isAdmin {
+		responseArray, err = decorateResourceList(resources, operation.identifierExtractor, operation.labelExtractor, operationContext.resourceControls, operationContext.ownershipLabels)
+	} else {
+		responseArray, err = filterResourceList(resources, operation.identifierExtractor, operation.labelExtractor, operationContext.resourceControls, operationContext.ownershipLabels, operationContext.userID, operationContext.userTeamIDs)
+	}
+	if err != nil {
+		return err
+	}
+
+	return rewriteResponse(response, responseArray, http.StatusOK)
+}
+
+// inspectOperation decodes the response via the bound decodeInspect function, verifies that the
+// user has access to the resource based on resource control (including, when a labelExtractor is
+// set, the indirect resource control carried by an owning label) and either rewrites an access
+// denied response or a decorated resource.
+func (operation *resourceOperation) inspectOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	resource, err := operation.decodeInspect(response.Body)
+	if err != nil {
+		return err
+	}
+
+	identifier := operation.identifierExtractor(resource)
+	if identifier == "" {
+		return ErrDockerResourceIdentifierNotFound
+	}
+
+	wrapper := &resourceWrapper{resource: resource}
+
+	// A direct resource control always takes precedence over one derived from an ownership
+	// label, matching the precedence decorateResourceList/filterResourceList apply to the
+	// equivalent list operation, so that the same resource reports the same owner on both.
+	resourceControl := getResourceControlByResourceID(identifier, operationContext.resourceControls)
+	if resourceControl == nil && operation.labelExtractor != nil {
+		labels := operation.labelExtractor(resource)
+		resourceControl = resolveOwnershipResourceControl(labels, operationContext.ownershipLabels, operationContext.resourceControls)
+	}
+
+	if resourceControl != nil {
+		if operationContext.isAdmin || canUserAccessResource(operationContext.userID, operationContext.userTeamIDs, resourceControl) {
+			wrapper.Portainer = resourceControl
+		} else {
+			return rewriteAccessDeniedResponse(response)
+		}
+	}
+
+	return rewriteResponse(response, wrapper, http.StatusOK)
+}
+
+// decorateResourceList binds each resource to its resource control, if any, so that the response
+// carries the Portainer field required by the UI to manage ownership. When a resource has no
+// direct resource control, it falls back to the resource control bound to its ownership labels.
+func decorateResourceList(resources []interface{}, identifierExtractor IdentifierExtractor, labelExtractor LabelExtractor, resourceControls []portainer.ResourceControl, ownershipLabels []string) ([]interface{}, error) {
+	decorated := make([]interface{}, 0, len(resources))
+	for _, resource := range resources {
+		resourceControl := getResourceControlByResourceID(identifierExtractor(resource), resourceControls)
+		if resourceControl == nil && labelExtractor != nil {
+			resourceControl = resolveOwnershipResourceControl(labelExtractor(resource), ownershipLabels, resourceControls)
+		}
+		decorated = append(decorated, &resourceWrapper{resource: resource, Portainer: resourceControl})
+	}
+	return decorated, nil
+}
+
+// filterResourceList keeps resources that carry no resource control (public resources) as well
+// as resources the user can access, either directly or through their ownership labels, and
+// strips out the rest.
+func filterResourceList(resources []interface{}, identifierExtractor IdentifierExtractor, labelExtractor LabelExtractor, resourceControls []portainer.ResourceControl, ownershipLabels []string, userID portainer.UserID, userTeamIDs []portainer.TeamID) ([]interface{}, error) {
+	filtered := make([]interface{}, 0, len(resources))
+	for _, resource := range resources {
+		resourceControl := getResourceControlByResourceID(identifierExtractor(resource), resourceControls)
+		if resourceControl == nil && labelExtractor != nil {
+			resourceControl = resolveOwnershipResourceControl(labelExtractor(resource), ownershipLabels, resourceControls)
+		}
+		if resourceControl == nil {
+			filtered = append(filtered, resource)
+			continue
+		}
+		if canUserAccessResource(userID, userTeamIDs, resourceControl) {
+			filtered = append(filtered, &resourceWrapper{resource: resource, Portainer: resourceControl})
+		}
+	}
+	return filtered, nil
+}
@@ -0,0 +1,234 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/portainer/portainer"
+)
+
+// resourceOperationTest describes a single recorded Docker response fed through a resourceOperation.
+type resourceOperationTest struct {
+	kind               ResourceKind
+	listResponse       string
+	inspectResponse    string
+	restrictedID       string
+	unrestrictedIDList bool
+}
+
+var resourceOperationTests = []resourceOperationTest{
+	{
+		kind:            ResourceKindContainer,
+		listResponse:    `[{"Id":"container1","Labels":{}},{"Id":"container2","Labels":{}}]`,
+		inspectResponse: `{"Id":"container1","Config":{"Labels":{}}}`,
+		restrictedID:    "container1",
+	},
+	{
+		kind:            ResourceKindService,
+		listResponse:    `[{"ID":"service1"},{"ID":"service2"}]`,
+		inspectResponse: `{"ID":"service1"}`,
+		restrictedID:    "service1",
+	},
+	{
+		kind:            ResourceKindNetwork,
+		listResponse:    `[{"Id":"network1"},{"Id":"network2"}]`,
+		inspectResponse: `{"Id":"network1"}`,
+		restrictedID:    "network1",
+	},
+	{
+		kind:            ResourceKindVolume,
+		listResponse:    `{"Volumes":[{"Name":"volume1"},{"Name":"volume2"}],"Warnings":null}`,
+		inspectResponse: `{"Name":"volume1"}`,
+		restrictedID:    "volume1",
+	},
+	{
+		kind:            ResourceKindSecret,
+		listResponse:    `[{"ID":"secret1"},{"ID":"secret2"}]`,
+		inspectResponse: `{"ID":"secret1"}`,
+		restrictedID:    "secret1",
+	},
+	{
+		kind:            ResourceKindConfig,
+		listResponse:    `[{"ID":"config1"},{"ID":"config2"}]`,
+		inspectResponse: `{"ID":"config1"}`,
+		restrictedID:    "config1",
+	},
+	{
+		kind:            ResourceKindTask,
+		listResponse:    `[{"ID":"task1"},{"ID":"task2"}]`,
+		inspectResponse: `{"ID":"task1"}`,
+		restrictedID:    "task1",
+	},
+	{
+		kind:            ResourceKindNode,
+		listResponse:    `[{"ID":"node1"},{"ID":"node2"}]`,
+		inspectResponse: `{"ID":"node1"}`,
+		restrictedID:    "node1",
+	},
+	{
+		kind:            ResourceKindPlugin,
+		listResponse:    `[{"Id":"plugin1"},{"Id":"plugin2"}]`,
+		inspectResponse: `{"Id":"plugin1"}`,
+		restrictedID:    "plugin1",
+	},
+}
+
+// checkpointListOperationTests covers ResourceKindCheckpoint separately: the Docker API has no
+// checkpoint inspect endpoint, so this kind only registers a listOperation.
+var checkpointListOperationTests = []resourceOperationTest{
+	{
+		kind:         ResourceKindCheckpoint,
+		listResponse: `[{"Name":"checkpoint1"},{"Name":"checkpoint2"}]`,
+		restrictedID: "checkpoint1",
+	},
+}
+
+func newRestrictedOperationContext(isAdmin bool, restrictedID string) *restrictedOperationContext {
+	return &restrictedOperationContext{
+		isAdmin:     isAdmin,
+		userID:      portainer.UserID(1),
+		userTeamIDs: nil,
+		resourceControls: []portainer.ResourceControl{
+			{ResourceID: restrictedID, UserAccesses: []portainer.UserResourceAccess{}},
+		},
+	}
+}
+
+func Test_resourceOperation_listOperation_adminSeesDecoratedResources(t *testing.T) {
+	for _, test := range append(append([]resourceOperationTest{}, resourceOperationTests...), checkpointListOperationTests...) {
+		operation := resourceOperations[test.kind]
+
+		response := &http.Response{Body: newJSONBody(test.listResponse)}
+		operationContext := newRestrictedOperationContext(true, test.restrictedID)
+
+		if err := operation.listOperation(nil, response, operationContext); err != nil {
+			t.Fatalf("%v: unexpected error: %s", test.kind, err)
+		}
+
+		var decoded []map[string]interface{}
+		if err := json.NewDecoder(response.Body).Decode(&decoded); err != nil {
+			t.Fatalf("%v: unable to decode rewritten response: %s", test.kind, err)
+		}
+
+		if len(decoded) != 2 {
+			t.Fatalf("%v: expected 2 resources, got %d", test.kind, len(decoded))
+		}
+		if decoded[0]["Portainer"] == nil {
+			t.Errorf("%v: expected restricted resource to carry a Portainer field", test.kind)
+		}
+	}
+}
+
+func Test_resourceOperation_listOperation_nonAdminOnlySeesAccessibleResources(t *testing.T) {
+	for _, test := range append(append([]resourceOperationTest{}, resourceOperationTests...), checkpointListOperationTests...) {
+		operation := resourceOperations[test.kind]
+
+		response := &http.Response{Body: newJSONBody(test.listResponse)}
+		operationContext := newRestrictedOperationContext(false, test.restrictedID)
+
+		if err := operation.listOperation(nil, response, operationContext); err != nil {
+			t.Fatalf("%v: unexpected error: %s", test.kind, err)
+		}
+
+		var decoded []map[string]interface{}
+		if err := json.NewDecoder(response.Body).Decode(&decoded); err != nil {
+			t.Fatalf("%v: unable to decode rewritten response: %s", test.kind, err)
+		}
+
+		if len(decoded) != 1 {
+			t.Fatalf("%v: expected the restricted resource to be filtered out, got %d resources", test.kind, len(decoded))
+		}
+	}
+}
+
+func Test_resourceOperation_inspectOperation_accessDeniedForNonAdmin(t *testing.T) {
+	for _, test := range resourceOperationTests {
+		operation := resourceOperations[test.kind]
+
+		response := &http.Response{Body: newJSONBody(test.inspectResponse)}
+		operationContext := newRestrictedOperationContext(false, test.restrictedID)
+
+		err := operation.inspectOperation(nil, response, operationContext)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %s", test.kind, err)
+		}
+
+		var decoded map[string]interface{}
+		if err := json.NewDecoder(response.Body).Decode(&decoded); err != nil {
+			t.Fatalf("%v: unable to decode rewritten response: %s", test.kind, err)
+		}
+
+		if decoded["message"] == nil {
+			t.Errorf("%v: expected an access denied response, got %v", test.kind, decoded)
+		}
+	}
+}
+
+// Test_resourceOperation_inspectAndList_agreeOnResourceControlPrecedence guards against
+// inspectOperation and decorateResourceList disagreeing on which resource control wins when a
+// resource carries both a direct one and one derived from an ownership label: the same container
+// must report the same Portainer owner from GET /containers/json and GET /containers/{id}/json.
+func Test_resourceOperation_inspectAndList_agreeOnResourceControlPrecedence(t *testing.T) {
+	operation := resourceOperations[ResourceKindContainer]
+
+	operationContext := &restrictedOperationContext{
+		isAdmin: true,
+		resourceControls: []portainer.ResourceControl{
+			{ResourceID: "container1"},
+			{ResourceID: "service1"},
+		},
+	}
+
+	inspectResponse := &http.Response{Body: newJSONBody(`{"Id":"container1","Config":{"Labels":{"com.docker.swarm.service.id":"service1"}}}`)}
+	if err := operation.inspectOperation(nil, inspectResponse, operationContext); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var inspected map[string]interface{}
+	if err := json.NewDecoder(inspectResponse.Body).Decode(&inspected); err != nil {
+		t.Fatalf("unable to decode rewritten inspect response: %s", err)
+	}
+	inspectedPortainer, ok := inspected["Portainer"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected inspect response to carry a Portainer field, got %v", inspected)
+	}
+
+	listResponse := &http.Response{Body: newJSONBody(`[{"Id":"container1","Labels":{"com.docker.swarm.service.id":"service1"}}]`)}
+	if err := operation.listOperation(nil, listResponse, operationContext); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var listed []map[string]interface{}
+	if err := json.NewDecoder(listResponse.Body).Decode(&listed); err != nil {
+		t.Fatalf("unable to decode rewritten list response: %s", err)
+	}
+	listedPortainer, ok := listed[0]["Portainer"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected listed resource to carry a Portainer field, got %v", listed[0])
+	}
+
+	if inspectedPortainer["ResourceID"] != "container1" {
+		t.Errorf("expected the inspect path to prefer the direct resource control, got %v", inspectedPortainer)
+	}
+	if listedPortainer["ResourceID"] != "container1" {
+		t.Errorf("expected the list path to prefer the direct resource control, got %v", listedPortainer)
+	}
+	if inspectedPortainer["ResourceID"] != listedPortainer["ResourceID"] {
+		t.Errorf("expected inspect and list paths to agree on the owning resource control, got inspect=%v list=%v", inspectedPortainer, listedPortainer)
+	}
+}
+
+func newJSONBody(content string) *jsonBody {
+	return &jsonBody{bytes.NewBufferString(content)}
+}
+
+// jsonBody adapts a bytes.Buffer to io.ReadCloser so it can stand in for an http.Response body.
+type jsonBody struct {
+	*bytes.Buffer
+}
+
+func (b *jsonBody) Close() error {
+	return nil
+}
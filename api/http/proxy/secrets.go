@@ -0,0 +1,62 @@
+package proxy
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+func init() {
+	registerResourceOperation(&resourceOperation{
+		kind:                ResourceKindSecret,
+		decodeList:          decodeSecretList,
+		decodeInspect:       decodeSecretInspect,
+		identifierExtractor: secretIdentifier,
+	})
+}
+
+// secretListOperation decodes the response into an array of swarm.Secret, decorate and/or
+// filter the secrets based on resource controls before rewriting the response.
+// https://docs.docker.com/engine/api/v1.28/#operation/SecretList
+func secretListOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	return resourceOperations[ResourceKindSecret].listOperation(request, response, operationContext)
+}
+
+// secretInspectOperation decodes the response into a swarm.Secret, verify that the user has
+// access to the secret based on resource control and either rewrite an access denied response
+// or a decorated secret.
+// https://docs.docker.com/engine/api/v1.28/#operation/SecretInspect
+func secretInspectOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	return resourceOperations[ResourceKindSecret].inspectOperation(request, response, operationContext)
+}
+
+func decodeSecretList(body io.Reader) ([]interface{}, error) {
+	var secrets []swarm.Secret
+	if err := json.NewDecoder(body).Decode(&secrets); err != nil {
+		return nil, err
+	}
+
+	resources := make([]interface{}, len(secrets))
+	for i := range secrets {
+		resources[i] = secrets[i]
+	}
+	return resources, nil
+}
+
+func decodeSecretInspect(body io.Reader) (interface{}, error) {
+	var secret swarm.Secret
+	if err := json.NewDecoder(body).Decode(&secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+func secretIdentifier(resource interface{}) string {
+	secret, ok := resource.(swarm.Secret)
+	if !ok {
+		return ""
+	}
+	return secret.ID
+}
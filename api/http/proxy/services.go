@@ -0,0 +1,62 @@
+package proxy
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+func init() {
+	registerResourceOperation(&resourceOperation{
+		kind:                ResourceKindService,
+		decodeList:          decodeServiceList,
+		decodeInspect:       decodeServiceInspect,
+		identifierExtractor: serviceIdentifier,
+	})
+}
+
+// serviceListOperation decodes the response into an array of swarm.Service, decorate
+// and/or filter the services based on resource controls before rewriting the response.
+// https://docs.docker.com/engine/api/v1.28/#operation/ServiceList
+func serviceListOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	return resourceOperations[ResourceKindService].listOperation(request, response, operationContext)
+}
+
+// serviceInspectOperation decodes the response into a swarm.Service, verify that the user has
+// access to the service based on resource control and either rewrite an access denied response
+// or a decorated service.
+// https://docs.docker.com/engine/api/v1.28/#operation/ServiceInspect
+func serviceInspectOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	return resourceOperations[ResourceKindService].inspectOperation(request, response, operationContext)
+}
+
+func decodeServiceList(body io.Reader) ([]interface{}, error) {
+	var services []swarm.Service
+	if err := json.NewDecoder(body).Decode(&services); err != nil {
+		return nil, err
+	}
+
+	resources := make([]interface{}, len(services))
+	for i := range services {
+		resources[i] = services[i]
+	}
+	return resources, nil
+}
+
+func decodeServiceInspect(body io.Reader) (interface{}, error) {
+	var service swarm.Service
+	if err := json.NewDecoder(body).Decode(&service); err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+func serviceIdentifier(resource interface{}) string {
+	service, ok := resource.(swarm.Service)
+	if !ok {
+		return ""
+	}
+	return service.ID
+}
@@ -0,0 +1,181 @@
+package proxy
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+
+	"github.com/portainer/portainer"
+)
+
+// streamingOperation proxies a Docker response body to the client without buffering it, for
+// endpoints whose response cannot be read into memory and rewritten: logs, stats with stream=1
+// and attach. Access control is still enforced, by resolving the resource control of the
+// container the request targets through a side inspect call, before any byte of the upstream
+// response reaches the client. The cluster-wide /events stream has no single owning resource to
+// check and is handled separately by eventsOperation.
+type streamingOperation struct {
+	kind                ResourceKind
+	identifierExtractor func(request *http.Request) string
+	inspect             func(endpointID portainer.EndpointID, identifier string) (interface{}, error)
+}
+
+func registerStreamingOperation(operation *streamingOperation) {
+	streamingOperations[operation.kind] = operation
+}
+
+// streamingOperations holds the streamingOperation registered for each ResourceKind that exposes
+// a streaming endpoint.
+var streamingOperations = map[ResourceKind]*streamingOperation{}
+
+// streamResponse checks that the user has access to the resource targeted by the request and,
+// on success, copies the (potentially chunked or hijacked) response straight through to the
+// client instead of buffering and rewriting it.
+func (operation *streamingOperation) streamResponse(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	identifier := operation.identifierExtractor(request)
+
+	resource, err := operation.inspect(operationContext.endpointID, identifier)
+	if err != nil {
+		return err
+	}
+
+	resourceOp := resourceOperations[operation.kind]
+	ownerID := resourceOp.identifierExtractor(resource)
+	if ownerID == "" {
+		// The side inspect call succeeded but its result carries no identifier of its own kind.
+		// That should never happen for a container that is actually still there; fail closed for
+		// non-admins instead of silently letting an unresolved owner skip the access check below,
+		// while administrators are still streamed the response.
+		if !operationContext.isAdmin {
+			return rewriteAccessDeniedResponse(response)
+		}
+		return proxyStreamedResponse(request, response)
+	}
+
+	resourceControl := getResourceControlByResourceID(ownerID, operationContext.resourceControls)
+	if resourceControl != nil && !operationContext.isAdmin && !canUserAccessResource(operationContext.userID, operationContext.userTeamIDs, resourceControl) {
+		return rewriteAccessDeniedResponse(response)
+	}
+
+	if resourceOp.labelExtractor != nil {
+		labels := resourceOp.labelExtractor(resource)
+		ownerControl := resolveOwnershipResourceControl(labels, operationContext.ownershipLabels, operationContext.resourceControls)
+		if ownerControl != nil && !operationContext.isAdmin && !canUserAccessResource(operationContext.userID, operationContext.userTeamIDs, ownerControl) {
+			return rewriteAccessDeniedResponse(response)
+		}
+	}
+
+	return proxyStreamedResponse(request, response)
+}
+
+// eventsOperation proxies the Docker /events stream. Unlike the other streamed endpoints, an
+// event stream is cluster-wide rather than bound to a single Docker resource, so there is no
+// resource control to resolve: access is simply restricted to administrators.
+// https://docs.docker.com/engine/api/v1.28/#operation/SystemEvents
+func eventsOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	if !operationContext.isAdmin {
+		return rewriteAccessDeniedResponse(response)
+	}
+
+	return proxyStreamedResponse(request, response)
+}
+
+// proxyStreamedResponse copies the upstream response through to the client as-is, preserving
+// Transfer-Encoding: chunked, and proxies hijacked connections (e.g. attach) bidirectionally.
+func proxyStreamedResponse(request *http.Request, response *http.Response) error {
+	writer, ok := request.Context().Value(responseWriterContextKey).(http.ResponseWriter)
+	if !ok {
+		return ErrResponseWriterNotFound
+	}
+
+	for key, values := range response.Header {
+		for _, value := range values {
+			writer.Header().Add(key, value)
+		}
+	}
+	writer.WriteHeader(response.StatusCode)
+
+	if response.StatusCode == http.StatusSwitchingProtocols {
+		return hijackAndProxy(writer, response)
+	}
+
+	flusher, isFlusher := writer.(http.Flusher)
+	reader := bufio.NewReader(response.Body)
+	buffer := make([]byte, 4096)
+	for {
+		n, readErr := reader.Read(buffer)
+		if n > 0 {
+			if _, writeErr := writer.Write(buffer[:n]); writeErr != nil {
+				return writeErr
+			}
+			if isFlusher {
+				flusher.Flush()
+			}
+		}
+		if readErr == io.EOF {
+			return nil
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+}
+
+// hijackAndProxy takes over the client connection (used for /containers/{id}/attach) and proxies
+// bytes bidirectionally between it and the hijacked upstream Docker connection until either side
+// closes.
+func hijackAndProxy(writer http.ResponseWriter, response *http.Response) error {
+	hijacker, ok := writer.(http.Hijacker)
+	if !ok {
+		return ErrResponseNotHijackable
+	}
+
+	clientConn, _, err := hijacker.Hijack()
+	if err != nil {
+		return err
+	}
+	defer clientConn.Close()
+
+	upstreamConn, ok := response.Body.(io.ReadWriteCloser)
+	if !ok {
+		return ErrUpstreamNotHijackable
+	}
+	defer upstreamConn.Close()
+
+	errc := make(chan error, 2)
+	go func() {
+		_, err := io.Copy(upstreamConn, clientConn)
+		errc <- err
+	}()
+	go func() {
+		_, err := io.Copy(clientConn, upstreamConn)
+		errc <- err
+	}()
+
+	return <-errc
+}
+
+// responseWriterContextKeyType is the type used for the context key carrying the
+// http.ResponseWriter a streamingOperation writes to.
+type responseWriterContextKeyType int
+
+const responseWriterContextKey responseWriterContextKeyType = 0
+
+const (
+	// ErrResponseWriterNotFound defines an error raised when no http.ResponseWriter can be
+	// retrieved from the request context of a streaming operation.
+	ErrResponseWriterNotFound = streamingError("Unable to retrieve the response writer of a streamed request")
+	// ErrResponseNotHijackable defines an error raised when the response writer of a streamed
+	// request does not support hijacking (required to proxy an attach connection).
+	ErrResponseNotHijackable = streamingError("Unable to hijack the response writer of a streamed request")
+	// ErrUpstreamNotHijackable defines an error raised when the upstream Docker connection of a
+	// streamed request does not support being proxied as a raw connection.
+	ErrUpstreamNotHijackable = streamingError("Unable to proxy the hijacked upstream Docker connection")
+)
+
+// streamingError is a simple string-backed error, following the same pattern as portainer.Error.
+type streamingError string
+
+func (e streamingError) Error() string {
+	return string(e)
+}
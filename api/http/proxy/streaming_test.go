@@ -0,0 +1,191 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/portainer/portainer"
+)
+
+func requestWithResponseWriter(writer http.ResponseWriter) *http.Request {
+	request := httptest.NewRequest(http.MethodGet, "/containers/container1/logs", nil)
+	return request.WithContext(context.WithValue(request.Context(), responseWriterContextKey, writer))
+}
+
+func Test_proxyStreamedResponse_copiesStatusHeadersAndBody(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	request := requestWithResponseWriter(recorder)
+
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"status":"start"}`)),
+	}
+
+	if err := proxyStreamedResponse(request, response); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", recorder.Code)
+	}
+	if recorder.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("expected Content-Type header to be proxied through, got %q", recorder.Header().Get("Content-Type"))
+	}
+	if recorder.Body.String() != `{"status":"start"}` {
+		t.Errorf("expected body to be streamed through untouched, got %q", recorder.Body.String())
+	}
+}
+
+func Test_streamingOperation_streamResponse_deniesAccessForRestrictedContainer(t *testing.T) {
+	operation := &streamingOperation{
+		kind:                ResourceKindContainer,
+		identifierExtractor: func(*http.Request) string { return "container1" },
+		inspect: func(portainer.EndpointID, string) (interface{}, error) {
+			return decodeContainerInspect(strings.NewReader(`{"Id":"container1","Config":{"Labels":{}}}`))
+		},
+	}
+
+	response := &http.Response{Body: newJSONBody("")}
+	operationContext := newRestrictedOperationContext(false, "container1")
+
+	request := httptest.NewRequest(http.MethodGet, "/containers/container1/logs", nil)
+	if err := operation.streamResponse(request, response, operationContext); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.NewDecoder(response.Body).Decode(&decoded); err != nil {
+		t.Fatalf("unable to decode rewritten response: %s", err)
+	}
+	if decoded["message"] == nil {
+		t.Errorf("expected an access denied response, got %v", decoded)
+	}
+}
+
+func Test_streamingOperation_streamResponse_allowsAdminThrough(t *testing.T) {
+	operation := &streamingOperation{
+		kind:                ResourceKindContainer,
+		identifierExtractor: func(*http.Request) string { return "container1" },
+		inspect: func(portainer.EndpointID, string) (interface{}, error) {
+			return decodeContainerInspect(strings.NewReader(`{"Id":"container1","Config":{"Labels":{}}}`))
+		},
+	}
+
+	recorder := httptest.NewRecorder()
+	request := requestWithResponseWriter(recorder)
+
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader("log line\n")),
+	}
+	operationContext := newRestrictedOperationContext(true, "container1")
+
+	if err := operation.streamResponse(request, response, operationContext); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if recorder.Body.String() != "log line\n" {
+		t.Errorf("expected streamed body to be copied through, got %q", recorder.Body.String())
+	}
+}
+
+func Test_streamingOperation_streamResponse_deniesNonAdminWhenOwnerUnresolved(t *testing.T) {
+	operation := &streamingOperation{
+		kind:                ResourceKindContainer,
+		identifierExtractor: func(*http.Request) string { return "container1" },
+		inspect: func(portainer.EndpointID, string) (interface{}, error) {
+			// Simulates the side inspect call returning a resource the decoder understood but
+			// that carries no identifier of its own kind, the way a Docker error body silently
+			// decodes into a zero-value container instead of surfacing as an error.
+			return decodeContainerInspect(strings.NewReader(`{}`))
+		},
+	}
+
+	response := &http.Response{Body: newJSONBody("")}
+	operationContext := newRestrictedOperationContext(false, "container1")
+
+	request := httptest.NewRequest(http.MethodGet, "/containers/container1/logs", nil)
+	if err := operation.streamResponse(request, response, operationContext); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.NewDecoder(response.Body).Decode(&decoded); err != nil {
+		t.Fatalf("unable to decode rewritten response: %s", err)
+	}
+	if decoded["message"] == nil {
+		t.Errorf("expected an access denied response when the owner cannot be resolved, got %v", decoded)
+	}
+}
+
+func Test_streamingOperation_streamResponse_allowsAdminWhenOwnerUnresolved(t *testing.T) {
+	operation := &streamingOperation{
+		kind:                ResourceKindContainer,
+		identifierExtractor: func(*http.Request) string { return "container1" },
+		inspect: func(portainer.EndpointID, string) (interface{}, error) {
+			return decodeContainerInspect(strings.NewReader(`{}`))
+		},
+	}
+
+	recorder := httptest.NewRecorder()
+	request := requestWithResponseWriter(recorder)
+
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader("log line\n")),
+	}
+	operationContext := newRestrictedOperationContext(true, "container1")
+
+	if err := operation.streamResponse(request, response, operationContext); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if recorder.Body.String() != "log line\n" {
+		t.Errorf("expected an admin to still be streamed the response, got %q", recorder.Body.String())
+	}
+}
+
+func Test_eventsOperation_deniesNonAdmin(t *testing.T) {
+	response := &http.Response{Body: newJSONBody("")}
+	operationContext := newRestrictedOperationContext(false, "")
+
+	request := httptest.NewRequest(http.MethodGet, "/events", nil)
+	if err := eventsOperation(request, response, operationContext); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.NewDecoder(response.Body).Decode(&decoded); err != nil {
+		t.Fatalf("unable to decode rewritten response: %s", err)
+	}
+	if decoded["message"] == nil {
+		t.Errorf("expected an access denied response, got %v", decoded)
+	}
+}
+
+func Test_eventsOperation_streamsForAdmin(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	request := requestWithResponseWriter(recorder)
+
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(`{"status":"create"}`)),
+	}
+	operationContext := newRestrictedOperationContext(true, "")
+
+	if err := eventsOperation(request, response, operationContext); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if recorder.Body.String() != `{"status":"create"}` {
+		t.Errorf("expected event payload to be streamed through, got %q", recorder.Body.String())
+	}
+}
@@ -0,0 +1,62 @@
+package proxy
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+func init() {
+	registerResourceOperation(&resourceOperation{
+		kind:                ResourceKindTask,
+		decodeList:          decodeTaskList,
+		decodeInspect:       decodeTaskInspect,
+		identifierExtractor: taskIdentifier,
+	})
+}
+
+// taskListOperation decodes the response into an array of swarm.Task, decorate and/or filter
+// the tasks based on resource controls before rewriting the response.
+// https://docs.docker.com/engine/api/v1.28/#operation/TaskList
+func taskListOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	return resourceOperations[ResourceKindTask].listOperation(request, response, operationContext)
+}
+
+// taskInspectOperation decodes the response into a swarm.Task, verify that the user has access
+// to the task based on resource control and either rewrite an access denied response or a
+// decorated task.
+// https://docs.docker.com/engine/api/v1.28/#operation/TaskInspect
+func taskInspectOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	return resourceOperations[ResourceKindTask].inspectOperation(request, response, operationContext)
+}
+
+func decodeTaskList(body io.Reader) ([]interface{}, error) {
+	var tasks []swarm.Task
+	if err := json.NewDecoder(body).Decode(&tasks); err != nil {
+		return nil, err
+	}
+
+	resources := make([]interface{}, len(tasks))
+	for i := range tasks {
+		resources[i] = tasks[i]
+	}
+	return resources, nil
+}
+
+func decodeTaskInspect(body io.Reader) (interface{}, error) {
+	var task swarm.Task
+	if err := json.NewDecoder(body).Decode(&task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func taskIdentifier(resource interface{}) string {
+	task, ok := resource.(swarm.Task)
+	if !ok {
+		return ""
+	}
+	return task.ID
+}
@@ -0,0 +1,66 @@
+package proxy
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/volume"
+)
+
+func init() {
+	registerResourceOperation(&resourceOperation{
+		kind:                ResourceKindVolume,
+		decodeList:          decodeVolumeList,
+		decodeInspect:       decodeVolumeInspect,
+		identifierExtractor: volumeIdentifier,
+	})
+}
+
+// volumeListOperation decodes the response into a volume.VolumesListOKBody, decorate and/or
+// filter the volumes based on resource controls before rewriting the response.
+// https://docs.docker.com/engine/api/v1.28/#operation/VolumeList
+func volumeListOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	return resourceOperations[ResourceKindVolume].listOperation(request, response, operationContext)
+}
+
+// volumeInspectOperation decodes the response into a types.Volume, verify that the user has
+// access to the volume based on resource control and either rewrite an access denied response
+// or a decorated volume.
+// https://docs.docker.com/engine/api/v1.28/#operation/VolumeInspect
+func volumeInspectOperation(request *http.Request, response *http.Response, operationContext *restrictedOperationContext) error {
+	return resourceOperations[ResourceKindVolume].inspectOperation(request, response, operationContext)
+}
+
+// decodeVolumeList unwraps the Volumes array nested inside the VolumeList response body, Docker
+// Warnings are dropped as Portainer has no use for them.
+func decodeVolumeList(body io.Reader) ([]interface{}, error) {
+	var volumeListResponse volume.VolumesListOKBody
+	if err := json.NewDecoder(body).Decode(&volumeListResponse); err != nil {
+		return nil, err
+	}
+
+	resources := make([]interface{}, len(volumeListResponse.Volumes))
+	for i, volume := range volumeListResponse.Volumes {
+		resources[i] = *volume
+	}
+	return resources, nil
+}
+
+func decodeVolumeInspect(body io.Reader) (interface{}, error) {
+	var volume types.Volume
+	if err := json.NewDecoder(body).Decode(&volume); err != nil {
+		return nil, err
+	}
+	return volume, nil
+}
+
+// volumeIdentifier returns the Volume name, Docker volumes have no ID and are addressed by name.
+func volumeIdentifier(resource interface{}) string {
+	volume, ok := resource.(types.Volume)
+	if !ok {
+		return ""
+	}
+	return volume.Name
+}